@@ -48,6 +48,8 @@ func (c *Client) GetNode(ctx context.Context, nodeID string) (*Node, error) {
 	if nodeID == "" {
 		return nil, fmt.Errorf("nodeID is required")
 	}
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
 	req, err := c.newRequest(ctx, http.MethodGet, "/nodes/"+url.PathEscape(nodeID), nil)
 	if err != nil {
 		return nil, err
@@ -63,6 +65,8 @@ func (c *Client) GetNode(ctx context.Context, nodeID string) (*Node, error) {
 
 // ListNodes returns children of a given parent (unordered; sort by priority client-side).
 func (c *Client) ListNodes(ctx context.Context, parentID string) ([]*Node, error) {
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
 	path := "/nodes"
 	if parentID != "" {
 		path += "?parent_id=" + url.QueryEscape(parentID)
@@ -85,6 +89,8 @@ func (c *Client) CreateNode(ctx context.Context, in Create) (string, error) {
 	if in.Name == "" {
 		return "", fmt.Errorf("name is required")
 	}
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
 	req, err := c.newRequest(ctx, http.MethodPost, "/nodes", in)
 	if err != nil {
 		return "", err
@@ -106,6 +112,8 @@ func (c *Client) UpdateNode(ctx context.Context, nodeID string, in Update) error
 	if nodeID == "" {
 		return fmt.Errorf("nodeID is required")
 	}
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
 	req, err := c.newRequest(ctx, http.MethodPost, "/nodes/"+url.PathEscape(nodeID), in)
 	if err != nil {
 		return err
@@ -128,6 +136,8 @@ func (c *Client) MoveNode(ctx context.Context, nodeID string, in Move) error {
 	if in.ParentID == "" {
 		return fmt.Errorf("parentID is required")
 	}
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
 	req, err := c.newRequest(ctx, http.MethodPost, "/nodes/"+url.PathEscape(nodeID)+"/move", in)
 	if err != nil {
 		return err
@@ -147,6 +157,8 @@ func (c *Client) DeleteNode(ctx context.Context, nodeID string) error {
 	if nodeID == "" {
 		return fmt.Errorf("nodeID is required")
 	}
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
 	req, err := c.newRequest(ctx, http.MethodDelete, "/nodes/"+url.PathEscape(nodeID), nil)
 	if err != nil {
 		return err
@@ -159,6 +171,8 @@ func (c *Client) CompleteNode(ctx context.Context, nodeID string) error {
 	if nodeID == "" {
 		return fmt.Errorf("nodeID is required")
 	}
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
 	req, err := c.newRequest(ctx, http.MethodPost, "/nodes/"+url.PathEscape(nodeID)+"/complete", nil)
 	if err != nil {
 		return err
@@ -178,6 +192,8 @@ func (c *Client) UncompleteNode(ctx context.Context, nodeID string) error {
 	if nodeID == "" {
 		return fmt.Errorf("nodeID is required")
 	}
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
 	req, err := c.newRequest(ctx, http.MethodPost, "/nodes/"+url.PathEscape(nodeID)+"/uncomplete", nil)
 	if err != nil {
 		return err
@@ -194,6 +210,8 @@ func (c *Client) UncompleteNode(ctx context.Context, nodeID string) error {
 
 // ListTargets returns a list of targets.
 func (c *Client) ListTargets(ctx context.Context) ([]*Target, error) {
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
 	req, err := c.newRequest(ctx, http.MethodGet, "/targets", nil)
 	if err != nil {
 		return nil, err
@@ -210,6 +228,8 @@ func (c *Client) ListTargets(ctx context.Context) ([]*Target, error) {
 // ExportAll returns all nodes as a flat list.
 // This request is rate limited to 1 per minute.
 func (c *Client) ExportAll(ctx context.Context) ([]*Node, error) {
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
 	req, err := c.newRequest(ctx, http.MethodGet, "/nodes-export", nil)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,316 @@
+// Package workflowy / batch.go implements a client-side batch ("transaction") API that queues
+// node mutations and submits them as one call, since the underlying REST API has no multi-
+// operation transaction endpoint of its own.
+package workflowy
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Ref is an opaque handle to a node within a Batch. Batch.Create returns a Ref so later ops in
+// the same batch can target the new node before its real server ID is known; Batch.RefID wraps
+// an already-existing node ID so it can be used the same way.
+type Ref struct {
+	idx int    // index into Batch.ops for a node created within this batch, -1 for an external ID
+	id  string // known node ID; set immediately for external refs, filled in for batch refs on Commit
+}
+
+type batchOpKind int
+
+const (
+	batchOpCreate batchOpKind = iota
+	batchOpUpdate
+	batchOpMove
+	batchOpDelete
+	batchOpComplete
+	batchOpUncomplete
+)
+
+// BatchCreate is the input to Batch.Create. It embeds Create so the usual fields apply, plus
+// ParentRef so the new node can be parented under a node created earlier in the same batch.
+// ParentRef takes precedence over Create.ParentID when set.
+type BatchCreate struct {
+	Create
+	ParentRef *Ref
+}
+
+// BatchMove is the input to Batch.Move. It mirrors Move but allows the target parent to be
+// another ref queued earlier in the same batch via ParentRef.
+//
+// Commit captures the node's current parent via GetNode before applying the move, so rollback
+// can restore it automatically; FromParentID only needs to be set to override that (or to
+// provide it when GetNode fails). FromPosition has no automatic equivalent, since GetNode
+// reports a node's Priority but not the "top"/"bottom" position used to set it, so set it
+// explicitly if the restored position matters; otherwise rollback moves the node back to its
+// previous parent at MoveNode's default position.
+type BatchMove struct {
+	ParentRef    *Ref
+	ParentID     string
+	Position     *Position
+	FromParentID string
+	FromPosition *Position
+}
+
+// batchOp is one queued mutation plus whatever Commit needs to apply it and, on failure, undo it.
+type batchOp struct {
+	kind batchOpKind
+	ref  Ref // node this op targets: its own new ref for Create, the existing ref otherwise
+
+	create BatchCreate
+	update Update
+	move   BatchMove
+
+	resultID     string  // Create: the server-assigned ID
+	prevUpdate   *Update // Update: previous Name/Note/LayoutMode, captured before applying
+	prevParentID string  // Move: parent to restore on rollback, captured via GetNode or FromParentID; "" if neither is available
+	prevPosition *Position
+	prevComplete *bool // Complete/Uncomplete: previous Completed value, captured before applying
+}
+
+// Batch queues Create/Update/Move/Delete/Complete/Uncomplete operations against a Client and
+// submits them with a single Commit call. Commit applies ops in order using the client's normal
+// retry/rate-limit machinery and, on the first failure, walks the already-applied ops backwards
+// issuing compensating calls so a failed batch leaves as little of itself behind as possible.
+type Batch struct {
+	c   *Client
+	ops []*batchOp
+}
+
+// NewBatch starts a new batch of operations against c.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{c: c}
+}
+
+// RefID wraps an existing node ID as a Ref so it can be used alongside refs produced by Create.
+func (tx *Batch) RefID(nodeID string) Ref {
+	return Ref{idx: -1, id: nodeID}
+}
+
+// Create queues a CreateNode call and returns a Ref that later ops in this batch can target,
+// before the real node ID is known.
+func (tx *Batch) Create(in BatchCreate) Ref {
+	ref := Ref{idx: len(tx.ops)}
+	tx.ops = append(tx.ops, &batchOp{kind: batchOpCreate, ref: ref, create: in})
+	return ref
+}
+
+// Update queues an UpdateNode call against ref.
+func (tx *Batch) Update(ref Ref, in Update) {
+	tx.ops = append(tx.ops, &batchOp{kind: batchOpUpdate, ref: ref, update: in})
+}
+
+// Move queues a MoveNode call against ref.
+func (tx *Batch) Move(ref Ref, in BatchMove) {
+	tx.ops = append(tx.ops, &batchOp{kind: batchOpMove, ref: ref, move: in})
+}
+
+// Delete queues a DeleteNode call against ref. A delete cannot be undone by Commit's rollback;
+// if a later op fails, the delete stays applied.
+func (tx *Batch) Delete(ref Ref) {
+	tx.ops = append(tx.ops, &batchOp{kind: batchOpDelete, ref: ref})
+}
+
+// Complete queues a CompleteNode call against ref.
+func (tx *Batch) Complete(ref Ref) {
+	tx.ops = append(tx.ops, &batchOp{kind: batchOpComplete, ref: ref})
+}
+
+// Uncomplete queues an UncompleteNode call against ref.
+func (tx *Batch) Uncomplete(ref Ref) {
+	tx.ops = append(tx.ops, &batchOp{kind: batchOpUncomplete, ref: ref})
+}
+
+// BatchResult is the outcome of one queued op, in queue order.
+type BatchResult struct {
+	ID  string // the node ID the op acted on; for Create, the newly assigned ID
+	Err error  // non-nil if this specific op failed
+}
+
+// resolve returns the node ID ref points to, using the result of an earlier Create in this batch
+// when ref was produced by one.
+func (tx *Batch) resolve(ref Ref) (string, error) {
+	if ref.idx < 0 {
+		return ref.id, nil
+	}
+	if ref.idx >= len(tx.ops) {
+		return "", fmt.Errorf("workflowy: ref does not belong to this batch")
+	}
+	op := tx.ops[ref.idx]
+	if op.kind != batchOpCreate {
+		return "", fmt.Errorf("workflowy: ref does not point to a create op")
+	}
+	if op.resultID == "" {
+		return "", fmt.Errorf("workflowy: ref not yet resolved")
+	}
+	return op.resultID, nil
+}
+
+// Commit applies queued ops in order. On the first failure it stops, rolls back every op that
+// already succeeded (most recently applied first), and returns the results gathered so far
+// alongside the triggering error. The returned slice holds one entry per op actually attempted
+// (the failing op included, anything queued after it excluded), not one per queued op.
+func (tx *Batch) Commit(ctx context.Context) ([]BatchResult, error) {
+	results := make([]BatchResult, 0, len(tx.ops))
+	applied := 0
+	var commitErr error
+
+	for i, op := range tx.ops {
+		id, err := tx.apply(ctx, op)
+		results = append(results, BatchResult{ID: id, Err: err})
+		if err != nil {
+			commitErr = fmt.Errorf("workflowy: batch op %d: %w", i, err)
+			break
+		}
+		applied++
+	}
+
+	if commitErr != nil {
+		tx.rollback(ctx, applied)
+	}
+	return results, commitErr
+}
+
+// apply executes one op against the client and records whatever state rollback needs to undo it.
+func (tx *Batch) apply(ctx context.Context, op *batchOp) (string, error) {
+	switch op.kind {
+	case batchOpCreate:
+		in := op.create.Create
+		if op.create.ParentRef != nil {
+			parentID, err := tx.resolve(*op.create.ParentRef)
+			if err != nil {
+				return "", err
+			}
+			in.ParentID = parentID
+		}
+		id, err := tx.c.CreateNode(ctx, in)
+		if err != nil {
+			return "", err
+		}
+		op.resultID = id
+		return id, nil
+
+	case batchOpUpdate:
+		id, err := tx.resolve(op.ref)
+		if err != nil {
+			return "", err
+		}
+		if prev, gerr := tx.c.GetNode(ctx, id); gerr == nil {
+			op.prevUpdate = &Update{Name: &prev.Name, Note: prev.Note, LayoutMode: &prev.Data.LayoutMode}
+		}
+		if err := tx.c.UpdateNode(ctx, id, op.update); err != nil {
+			return id, err
+		}
+		return id, nil
+
+	case batchOpMove:
+		id, err := tx.resolve(op.ref)
+		if err != nil {
+			return "", err
+		}
+		parentID := op.move.ParentID
+		if op.move.ParentRef != nil {
+			parentID, err = tx.resolve(*op.move.ParentRef)
+			if err != nil {
+				return "", err
+			}
+		}
+		if op.move.FromParentID != "" {
+			op.prevParentID = op.move.FromParentID
+		} else if prev, gerr := tx.c.GetNode(ctx, id); gerr == nil {
+			op.prevParentID = prev.ParentID
+		}
+		op.prevPosition = op.move.FromPosition
+		if err := tx.c.MoveNode(ctx, id, Move{ParentID: parentID, Position: op.move.Position}); err != nil {
+			return id, err
+		}
+		return id, nil
+
+	case batchOpDelete:
+		id, err := tx.resolve(op.ref)
+		if err != nil {
+			return "", err
+		}
+		if err := tx.c.DeleteNode(ctx, id); err != nil {
+			return id, err
+		}
+		return id, nil
+
+	case batchOpComplete, batchOpUncomplete:
+		id, err := tx.resolve(op.ref)
+		if err != nil {
+			return "", err
+		}
+		if prev, gerr := tx.c.GetNode(ctx, id); gerr == nil {
+			op.prevComplete = &prev.Completed
+		}
+		var applyErr error
+		if op.kind == batchOpComplete {
+			applyErr = tx.c.CompleteNode(ctx, id)
+		} else {
+			applyErr = tx.c.UncompleteNode(ctx, id)
+		}
+		if applyErr != nil {
+			return id, applyErr
+		}
+		return id, nil
+	}
+	return "", fmt.Errorf("workflowy: unknown batch op kind %d", op.kind)
+}
+
+// rollbackTimeout bounds how long rollback is allowed to run for. Rollback detaches from the
+// Commit ctx it's handed (see below) so it needs its own deadline to avoid hanging forever if the
+// server stops responding.
+const rollbackTimeout = 30 * time.Second
+
+// rollback undoes the first n ops in tx.ops, most recently applied first. Deletes cannot be
+// undone and are skipped; everything else is best-effort, since the batch has already failed
+// and the original error takes precedence over anything rollback itself runs into.
+//
+// It runs on a context detached from ctx's cancellation/deadline (via context.WithoutCancel) and
+// bounded instead by rollbackTimeout: ctx is very often the thing that just caused Commit to
+// fail (most commonly an expired deadline), and compensating calls made with an already-dead ctx
+// would fail instantly without ever reaching the server.
+func (tx *Batch) rollback(ctx context.Context, n int) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+
+	for i := n - 1; i >= 0; i-- {
+		op := tx.ops[i]
+		switch op.kind {
+		case batchOpCreate:
+			if op.resultID != "" {
+				_ = tx.c.DeleteNode(ctx, op.resultID)
+			}
+
+		case batchOpUpdate:
+			if op.prevUpdate != nil {
+				if id, err := tx.resolve(op.ref); err == nil {
+					_ = tx.c.UpdateNode(ctx, id, *op.prevUpdate)
+				}
+			}
+
+		case batchOpMove:
+			if op.prevParentID != "" {
+				if id, err := tx.resolve(op.ref); err == nil {
+					_ = tx.c.MoveNode(ctx, id, Move{ParentID: op.prevParentID, Position: op.prevPosition})
+				}
+			}
+
+		case batchOpComplete, batchOpUncomplete:
+			if op.prevComplete != nil {
+				if id, err := tx.resolve(op.ref); err == nil {
+					if *op.prevComplete {
+						_ = tx.c.CompleteNode(ctx, id)
+					} else {
+						_ = tx.c.UncompleteNode(ctx, id)
+					}
+				}
+			}
+
+		case batchOpDelete:
+			// no-op: a deleted node cannot be restored through this API
+		}
+	}
+}
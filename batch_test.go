@@ -0,0 +1,151 @@
+package workflowy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestBatch_CommitAndRollback exercises a batch that creates a parent and child, then updates and
+// completes the child, then runs a second batch whose last op fails so Commit's rollback runs and
+// the child ends up as if that second batch never happened.
+func TestBatch_CommitAndRollback(t *testing.T) {
+	c := NewClient(testToken)
+	ctx := t.Context()
+
+	tx := c.NewBatch()
+	parent := tx.Create(BatchCreate{Create: Create{Name: "Batch Test Parent", Position: &PositionBottom}})
+	child := tx.Create(BatchCreate{Create: Create{Name: "Batch Test Child"}, ParentRef: &parent})
+	newName := "Batch Test Child Renamed"
+	tx.Update(child, Update{Name: &newName})
+	tx.Complete(child)
+
+	results, err := tx.Commit(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	for _, r := range results {
+		require.NoError(t, r.Err)
+	}
+	parentID := results[0].ID
+	childID := results[1].ID
+	require.NotEmpty(t, parentID)
+	require.NotEmpty(t, childID)
+	t.Logf("committed batch: parent=%s child=%s", parentID, childID)
+
+	node, err := c.GetNode(ctx, childID)
+	require.NoError(t, err)
+	require.Equal(t, newName, node.Name)
+	require.True(t, node.Completed)
+
+	// Now force a failing batch on the same child and confirm rollback restores it.
+	tx2 := c.NewBatch()
+	restoreName := "Batch Test Child Restored"
+	tx2.Update(tx2.RefID(childID), Update{Name: &restoreName})
+	tx2.Uncomplete(tx2.RefID(childID))
+	tx2.Delete(tx2.RefID("not-a-real-node-id"))
+
+	results2, err := tx2.Commit(ctx)
+	require.Error(t, err)
+	require.Len(t, results2, 3)
+	require.NoError(t, results2[0].Err)
+	require.NoError(t, results2[1].Err)
+	require.Error(t, results2[2].Err)
+
+	rolledBack, err := c.GetNode(ctx, childID)
+	require.NoError(t, err)
+	require.Equal(t, newName, rolledBack.Name)
+	require.True(t, rolledBack.Completed)
+	t.Logf("rolled back batch: child=%s", childID)
+
+	// Clean up: deleting the parent leaves the child (its child) orphaned under it, so delete
+	// both directly rather than going through another batch.
+	require.NoError(t, c.DeleteNode(ctx, childID))
+	require.NoError(t, c.DeleteNode(ctx, parentID))
+}
+
+// TestBatch_MoveRollback checks that Batch.Move captures a node's current parent automatically
+// via GetNode, and that a failed batch restores it without the caller having to pass
+// FromParentID itself.
+func TestBatch_MoveRollback(t *testing.T) {
+	c := NewClient(testToken)
+	ctx := t.Context()
+
+	oldParentID, err := c.CreateNode(ctx, Create{Name: "Batch Move Test Old Parent", Position: &PositionBottom})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.DeleteNode(ctx, oldParentID) })
+
+	newParentID, err := c.CreateNode(ctx, Create{Name: "Batch Move Test New Parent", Position: &PositionBottom})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.DeleteNode(ctx, newParentID) })
+
+	childID, err := c.CreateNode(ctx, Create{ParentID: oldParentID, Name: "Batch Move Test Child", Position: &PositionBottom})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.DeleteNode(ctx, childID) })
+
+	tx := c.NewBatch()
+	tx.Move(tx.RefID(childID), BatchMove{ParentID: newParentID})
+	tx.Delete(tx.RefID("not-a-real-node-id"))
+
+	results, err := tx.Commit(ctx)
+	require.Error(t, err)
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	require.Error(t, results[1].Err)
+
+	rolledBack, err := c.GetNode(ctx, childID)
+	require.NoError(t, err)
+	require.Equal(t, oldParentID, rolledBack.ParentID, "rollback should restore the node's original parent without FromParentID")
+}
+
+// TestBatch_RollbackUsesDetachedContext checks that rollback's compensating calls still reach
+// the server after Commit fails because its own ctx's deadline expired: rollback must detach
+// from that ctx rather than reuse it, or every compensating call would fail instantly without
+// ever being sent.
+func TestBatch_RollbackUsesDetachedContext(t *testing.T) {
+	deleteCalled := make(chan struct{}, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/nodes", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"item_id": "created-1"})
+	})
+	mux.HandleFunc("/nodes/created-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			select {
+			case deleteCalled <- struct{}{}:
+			default:
+			}
+		case http.MethodPost:
+			// Outlives the Commit ctx's deadline below, so the update fails with a context error.
+			time.Sleep(100 * time.Millisecond)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := NewClient("test-token")
+	c.SetBaseURL(srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	tx := c.NewBatch()
+	created := tx.Create(BatchCreate{Create: Create{Name: "Rollback Context Test"}})
+	newName := "renamed"
+	tx.Update(created, Update{Name: &newName})
+
+	_, err := tx.Commit(ctx)
+	require.Error(t, err)
+
+	select {
+	case <-deleteCalled:
+	case <-time.After(time.Second):
+		t.Fatal("rollback's compensating DeleteNode never reached the server; it likely reused the already-expired Commit context")
+	}
+}
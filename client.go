@@ -22,17 +22,25 @@ const BaseURL = "https://workflowy.com/api/v1"
 // It authenticates using an API key provided at construction time. Requests
 // are sent with an Authorization header using the Bearer scheme.
 type Client struct {
-	apiKey     string
-	baseURL    string
-	httpClient *http.Client
+	apiKey      string
+	baseURL     string
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
+	rateLimiter RateLimiter
+
+	defaultTimeout   time.Duration
+	roundTripHook    RoundTripHook
+	requestDecorator func(*http.Request)
 }
 
 // NewClient constructs a client using the default base URL, default HTTP client, and given API key.
 func NewClient(apiKey string) *Client {
 	return &Client{
-		apiKey:     strings.TrimSpace(apiKey),
-		baseURL:    BaseURL,
-		httpClient: DefaultHTTPClient(),
+		apiKey:      strings.TrimSpace(apiKey),
+		baseURL:     BaseURL,
+		httpClient:  DefaultHTTPClient(),
+		retryPolicy: DefaultRetryPolicy(),
+		rateLimiter: DefaultRateLimiter(),
 	}
 }
 
@@ -58,6 +66,24 @@ func (c *Client) SetHTTPClient(hc *http.Client) {
 	c.httpClient = hc
 }
 
+// SetRetryPolicy overrides how the client decides whether to retry a failed or throttled request
+// and how long to wait first. Passing nil is a no-op.
+func (c *Client) SetRetryPolicy(p RetryPolicy) {
+	if p == nil {
+		return
+	}
+	c.retryPolicy = p
+}
+
+// SetRateLimiter overrides the client-side rate limiter consulted before each request is sent.
+// Passing nil is a no-op.
+func (c *Client) SetRateLimiter(rl RateLimiter) {
+	if rl == nil {
+		return
+	}
+	c.rateLimiter = rl
+}
+
 // APIError represents a non-2xx response from the API.
 type APIError struct {
 	StatusCode int    `json:"-"`
@@ -125,48 +151,67 @@ func (c *Client) newRequest(ctx context.Context, method string, path string, bod
 	return req, nil
 }
 
-// do executes a request and handles 429 Too Many Requests errors by retrying.
+// do executes a request, pre-throttling it through the client's RateLimiter and, after each
+// attempt, consulting its RetryPolicy to decide whether to try again.
 func (c *Client) do(req *http.Request, v any) error {
-	const max429Retries = 3
 	for attempt := 0; ; attempt++ {
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(req.Context(), req); err != nil {
+				return err
+			}
+		}
+		if c.requestDecorator != nil {
+			c.requestDecorator(req)
+		}
+
 		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return err
+
+		if c.roundTripHook != nil {
+			c.roundTripHook(attempt, req, resp, err)
 		}
 
-		if resp.StatusCode == http.StatusTooManyRequests { // 429
-			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
-			resp.Body.Close()
-			if retryAfter <= 0 || attempt >= max429Retries {
-				return &APIError{StatusCode: resp.StatusCode, Message: "too many requests"}
-			}
-			select {
-			case <-req.Context().Done():
-				return req.Context().Err()
-			case <-time.After(retryAfter):
+		wait, retry := c.retryPolicy.ShouldRetry(attempt, req, resp, err)
+		if !retry {
+			if err != nil {
+				return err
 			}
-			if req.GetBody != nil && req.Body != nil {
-				if b, gerr := req.GetBody(); gerr == nil {
-					req.Body = b
-				}
-			}
-			continue
+			return c.decodeResponse(resp, v)
 		}
-
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			apiErr := &APIError{StatusCode: resp.StatusCode}
-			_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		if resp != nil {
 			resp.Body.Close()
-			return apiErr
 		}
-		if v == nil {
-			resp.Body.Close()
-			return nil
+
+		select {
+		case <-req.Context().Done():
+			return req.Context().Err()
+		case <-time.After(wait):
 		}
-		err = json.NewDecoder(resp.Body).Decode(v)
+		if req.GetBody != nil {
+			b, gerr := req.GetBody()
+			if gerr != nil {
+				return gerr
+			}
+			req.Body = b
+		}
+	}
+}
+
+// decodeResponse turns a completed, non-retried response into either an APIError or a decoded
+// value, closing the body either way.
+func (c *Client) decodeResponse(resp *http.Response, v any) error {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		resp.Body.Close()
+		return apiErr
+	}
+	if v == nil {
 		resp.Body.Close()
-		return err
+		return nil
 	}
+	err := json.NewDecoder(resp.Body).Decode(v)
+	resp.Body.Close()
+	return err
 }
 
 // parseRetryAfter parses a Retry-After header value which may be either a number of seconds
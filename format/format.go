@@ -0,0 +1,106 @@
+// Package format converts between Workflowy's flat node lists (as returned by
+// workflowy.Client.ExportAll) and common outline formats: OPML 2.0, CommonMark nested bullet
+// lists, and plain indented text.
+package format
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/unkn0wncode/workflowy"
+)
+
+// Item is one node in an outline tree, independent of whether it came from Workflowy, was parsed
+// from a file, or is about to be written to one.
+type Item struct {
+	Name       string
+	Note       string
+	LayoutMode workflowy.LayoutMode
+	Completed  bool
+	Children   []*Item
+}
+
+// FromNodes arranges a flat node list, such as the one Client.ExportAll returns, into a tree of
+// Items, each level sorted by Priority.
+func FromNodes(nodes []*workflowy.Node) []*Item {
+	byID := make(map[string]*Item, len(nodes))
+	priority := make(map[string]int, len(nodes))
+	childIDs := make(map[string][]string)
+	for _, n := range nodes {
+		note := ""
+		if n.Note != nil {
+			note = *n.Note
+		}
+		byID[n.ID] = &Item{Name: n.Name, Note: note, LayoutMode: n.Data.LayoutMode, Completed: n.Completed}
+		priority[n.ID] = n.Priority
+		childIDs[n.ParentID] = append(childIDs[n.ParentID], n.ID)
+	}
+	for parentID, ids := range childIDs {
+		sort.Slice(ids, func(i, j int) bool { return priority[ids[i]] < priority[ids[j]] })
+		parent, ok := byID[parentID]
+		if !ok {
+			continue // root-level IDs (parentID == "") have no Item of their own
+		}
+		for _, id := range ids {
+			parent.Children = append(parent.Children, byID[id])
+		}
+	}
+
+	rootIDs := append([]string(nil), childIDs[""]...)
+	sort.Slice(rootIDs, func(i, j int) bool { return priority[rootIDs[i]] < priority[rootIDs[j]] })
+	roots := make([]*Item, 0, len(rootIDs))
+	for _, id := range rootIDs {
+		roots = append(roots, byID[id])
+	}
+	return roots
+}
+
+// ToText serializes items as plain indented text, one line per item, indented one tab per
+// nesting level. Notes and layout are not represented.
+func ToText(items []*Item) []byte {
+	buf := &bytes.Buffer{}
+	writeTextItems(buf, items, 0)
+	return buf.Bytes()
+}
+
+func writeTextItems(buf *bytes.Buffer, items []*Item, depth int) {
+	for _, item := range items {
+		fmt.Fprintf(buf, "%s%s\n", strings.Repeat("\t", depth), item.Name)
+		writeTextItems(buf, item.Children, depth+1)
+	}
+}
+
+// ParseText parses plain indented text, as produced by ToText, into an outline tree. Nesting is
+// determined by the number of leading tabs.
+func ParseText(r io.Reader) ([]*Item, error) {
+	var roots []*Item
+	stack := map[int]*Item{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		depth := 0
+		for depth < len(line) && line[depth] == '\t' {
+			depth++
+		}
+		item := &Item{Name: line[depth:]}
+		if depth == 0 {
+			roots = append(roots, item)
+		} else if parent, ok := stack[depth-1]; ok {
+			parent.Children = append(parent.Children, item)
+		} else {
+			return nil, fmt.Errorf("format: parse text: line %q is indented past its parent", line)
+		}
+		stack[depth] = item
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
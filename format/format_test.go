@@ -0,0 +1,98 @@
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkn0wncode/workflowy"
+)
+
+func samplePtr(s string) *string { return &s }
+
+// TestFromNodes checks that a flat node list is arranged into a tree, sorted by Priority at each
+// level.
+func TestFromNodes(t *testing.T) {
+	nodes := []*workflowy.Node{
+		{ID: "b", ParentID: "", Name: "B", Priority: 1},
+		{ID: "a", ParentID: "", Name: "A", Priority: 0},
+		{ID: "a1", ParentID: "a", Name: "A1", Priority: 0, Note: samplePtr("a note")},
+	}
+	items := FromNodes(nodes)
+	require.Len(t, items, 2)
+	require.Equal(t, "A", items[0].Name)
+	require.Equal(t, "B", items[1].Name)
+	require.Len(t, items[0].Children, 1)
+	require.Equal(t, "A1", items[0].Children[0].Name)
+	require.Equal(t, "a note", items[0].Children[0].Note)
+}
+
+// TestOPMLRoundTrip checks that ToOPML followed by ParseOPML reproduces the original tree.
+func TestOPMLRoundTrip(t *testing.T) {
+	items := []*Item{
+		{Name: "Parent", Note: "a note", Children: []*Item{
+			{Name: "Child"},
+		}},
+	}
+	data, err := ToOPML(items)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `text="Parent"`)
+
+	parsed, err := ParseOPML(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+	require.Equal(t, "Parent", parsed[0].Name)
+	require.Equal(t, "a note", parsed[0].Note)
+	require.Len(t, parsed[0].Children, 1)
+	require.Equal(t, "Child", parsed[0].Children[0].Name)
+}
+
+// TestMarkdownRoundTrip checks that ToMarkdown followed by ParseMarkdown reproduces the original
+// tree, including todo/heading layout, completion, and notes.
+func TestMarkdownRoundTrip(t *testing.T) {
+	items := []*Item{
+		{Name: "Heading", LayoutMode: workflowy.LayoutModeHeading1, Children: []*Item{
+			{Name: "Done task", LayoutMode: workflowy.LayoutModeTodo, Completed: true},
+			{Name: "Open task", LayoutMode: workflowy.LayoutModeTodo, Note: "line one\nline two"},
+		}},
+	}
+	data, err := ToMarkdown(items)
+	require.NoError(t, err)
+	t.Logf("markdown:\n%s", data)
+
+	parsed, err := ParseMarkdown(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+	require.Equal(t, "Heading", parsed[0].Name)
+	require.Equal(t, workflowy.LayoutModeHeading1, parsed[0].LayoutMode)
+	require.Len(t, parsed[0].Children, 2)
+
+	done := parsed[0].Children[0]
+	require.Equal(t, "Done task", done.Name)
+	require.True(t, done.Completed)
+
+	open := parsed[0].Children[1]
+	require.Equal(t, "Open task", open.Name)
+	require.False(t, open.Completed)
+	require.Equal(t, "line one\nline two", open.Note)
+}
+
+// TestTextRoundTrip checks that ToText followed by ParseText reproduces the original tree shape.
+func TestTextRoundTrip(t *testing.T) {
+	items := []*Item{
+		{Name: "Root", Children: []*Item{
+			{Name: "Child", Children: []*Item{
+				{Name: "Grandchild"},
+			}},
+		}},
+	}
+	data := ToText(items)
+	parsed, err := ParseText(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+	require.Equal(t, "Root", parsed[0].Name)
+	require.Len(t, parsed[0].Children, 1)
+	require.Equal(t, "Child", parsed[0].Children[0].Name)
+	require.Len(t, parsed[0].Children[0].Children, 1)
+	require.Equal(t, "Grandchild", parsed[0].Children[0].Children[0].Name)
+}
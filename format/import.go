@@ -0,0 +1,64 @@
+// Package format / import.go implements import helpers that walk a parsed outline and create it
+// through a workflowy.Client.
+package format
+
+import (
+	"context"
+	"io"
+
+	"github.com/unkn0wncode/workflowy"
+)
+
+// ImportOPML parses an OPML 2.0 document from r and creates it as a subtree under parentID (or
+// at the root level if parentID is empty) through c, preserving order, nesting, and notes. It
+// returns the created node IDs in the order they were created (pre-order, matching the document).
+func ImportOPML(ctx context.Context, c *workflowy.Client, parentID string, r io.Reader) ([]string, error) {
+	items, err := ParseOPML(r)
+	if err != nil {
+		return nil, err
+	}
+	return importItems(ctx, c, parentID, items)
+}
+
+// ImportMarkdown parses a CommonMark nested bullet list from r and creates it as a subtree under
+// parentID (or at the root level if parentID is empty) through c, preserving order, nesting,
+// todo/heading layout, and notes. It returns the created node IDs in the order they were created
+// (pre-order, matching the document).
+func ImportMarkdown(ctx context.Context, c *workflowy.Client, parentID string, r io.Reader) ([]string, error) {
+	items, err := ParseMarkdown(r)
+	if err != nil {
+		return nil, err
+	}
+	return importItems(ctx, c, parentID, items)
+}
+
+// importItems walks items depth-first, issuing one CreateNode call per item so each ends up with
+// the right ParentID, Position, LayoutMode, and Note. It returns whatever node IDs were created
+// before a call failed, alongside the error.
+func importItems(ctx context.Context, c *workflowy.Client, parentID string, items []*Item) ([]string, error) {
+	var ids []string
+	for _, item := range items {
+		in := workflowy.Create{ParentID: parentID, Name: item.Name, Position: &workflowy.PositionBottom}
+		if item.Note != "" {
+			note := item.Note
+			in.Note = &note
+		}
+		if item.LayoutMode != "" {
+			layoutMode := item.LayoutMode
+			in.LayoutMode = &layoutMode
+		}
+
+		id, err := c.CreateNode(ctx, in)
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+
+		childIDs, err := importItems(ctx, c, id, item.Children)
+		ids = append(ids, childIDs...)
+		if err != nil {
+			return ids, err
+		}
+	}
+	return ids, nil
+}
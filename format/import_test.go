@@ -0,0 +1,71 @@
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkn0wncode/workflowy"
+)
+
+// TestImportMarkdown checks that a small Markdown outline is created as a matching subtree
+// through a real Client, then cleans up after itself.
+func TestImportMarkdown(t *testing.T) {
+	c := workflowy.NewClient(testToken)
+	ctx := t.Context()
+
+	md := "- Import Test Parent\n" +
+		"  - [ ] Import Test Child\n" +
+		"    > a note\n"
+
+	ids, err := ImportMarkdown(ctx, c, "", strings.NewReader(md))
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	t.Cleanup(func() { _ = c.DeleteNode(ctx, ids[0]) })
+
+	parent, err := c.GetNode(ctx, ids[0])
+	require.NoError(t, err)
+	require.Equal(t, "Import Test Parent", parent.Name)
+
+	children, err := c.ListNodes(ctx, ids[0])
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	require.Equal(t, ids[1], children[0].ID)
+	require.Equal(t, "Import Test Child", children[0].Name)
+	require.Equal(t, workflowy.LayoutModeTodo, children[0].Data.LayoutMode)
+	require.NotNil(t, children[0].Note)
+	require.Equal(t, "a note", *children[0].Note)
+}
+
+// TestImportOPML checks that a small OPML document is created as a matching subtree through a
+// real Client, then cleans up after itself.
+func TestImportOPML(t *testing.T) {
+	c := workflowy.NewClient(testToken)
+	ctx := t.Context()
+
+	opml := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head><title>Test</title></head>
+  <body>
+    <outline text="Import Test OPML Parent" _note="parent note">
+      <outline text="Import Test OPML Child"/>
+    </outline>
+  </body>
+</opml>`
+
+	ids, err := ImportOPML(ctx, c, "", strings.NewReader(opml))
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	t.Cleanup(func() { _ = c.DeleteNode(ctx, ids[0]) })
+
+	parent, err := c.GetNode(ctx, ids[0])
+	require.NoError(t, err)
+	require.Equal(t, "Import Test OPML Parent", parent.Name)
+	require.NotNil(t, parent.Note)
+	require.Equal(t, "parent note", *parent.Note)
+
+	children, err := c.ListNodes(ctx, ids[0])
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	require.Equal(t, "Import Test OPML Child", children[0].Name)
+}
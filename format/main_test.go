@@ -0,0 +1,27 @@
+package format
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+var testToken string
+
+// TestMain prepares the test environment by reading the API token from the .env file or
+// environment variable, the same way the workflowy package's own tests do.
+func TestMain(m *testing.M) {
+	if data, err := os.ReadFile("../.env"); err == nil {
+		for line := range strings.SplitSeq(string(data), "\n") {
+			if kv := strings.SplitN(line, "=", 2); len(kv) == 2 {
+				os.Setenv(kv[0], kv[1])
+			}
+		}
+	}
+	if testToken = os.Getenv("WORKFLOWY_API_KEY"); testToken == "" {
+		fmt.Fprintln(os.Stderr, "WORKFLOWY_API_KEY not set, skipping integration tests")
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,124 @@
+// Package format / markdown.go implements CommonMark nested bullet list conversion.
+package format
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/unkn0wncode/workflowy"
+)
+
+// ToMarkdown serializes items as a CommonMark nested bullet list, two spaces per nesting level.
+// Todo-layout items are written as "- [ ]"/"- [x]" checkboxes, following Item.Completed;
+// h1/h2/h3-layout items are written as "- #"/"- ##"/"- ###" headings so nesting is preserved.
+// Notes are written as indented blockquote lines under their item, one per line of the note.
+func ToMarkdown(items []*Item) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	writeMarkdownItems(buf, items, 0)
+	return buf.Bytes(), nil
+}
+
+func writeMarkdownItems(buf *bytes.Buffer, items []*Item, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, item := range items {
+		fmt.Fprintf(buf, "%s- %s\n", indent, markdownItemText(item))
+		if item.Note != "" {
+			noteIndent := strings.Repeat("  ", depth+1)
+			for _, line := range strings.Split(item.Note, "\n") {
+				fmt.Fprintf(buf, "%s> %s\n", noteIndent, line)
+			}
+		}
+		writeMarkdownItems(buf, item.Children, depth+1)
+	}
+}
+
+func markdownItemText(item *Item) string {
+	switch item.LayoutMode {
+	case workflowy.LayoutModeTodo:
+		if item.Completed {
+			return "[x] " + item.Name
+		}
+		return "[ ] " + item.Name
+	case workflowy.LayoutModeHeading1:
+		return "# " + item.Name
+	case workflowy.LayoutModeH2:
+		return "## " + item.Name
+	case workflowy.LayoutModeH3:
+		return "### " + item.Name
+	default:
+		return item.Name
+	}
+}
+
+// ParseMarkdown parses a CommonMark nested bullet list, as produced by ToMarkdown, into an
+// outline tree.
+func ParseMarkdown(r io.Reader) ([]*Item, error) {
+	var roots []*Item
+	stack := map[int]*Item{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := 0
+		for indent < len(line) && line[indent] == ' ' {
+			indent++
+		}
+		depth := indent / 2
+		rest := line[indent:]
+
+		switch {
+		case strings.HasPrefix(rest, "- "):
+			item := parseMarkdownItem(strings.TrimPrefix(rest, "- "))
+			if depth == 0 {
+				roots = append(roots, item)
+			} else if parent, ok := stack[depth-1]; ok {
+				parent.Children = append(parent.Children, item)
+			} else {
+				return nil, fmt.Errorf("format: parse markdown: item at depth %d has no parent", depth)
+			}
+			stack[depth] = item
+
+		case strings.HasPrefix(rest, "> "):
+			parent, ok := stack[depth-1]
+			if !ok {
+				return nil, fmt.Errorf("format: parse markdown: note at depth %d has no parent item", depth)
+			}
+			note := strings.TrimPrefix(rest, "> ")
+			if parent.Note != "" {
+				parent.Note += "\n" + note
+			} else {
+				parent.Note = note
+			}
+
+		default:
+			return nil, fmt.Errorf("format: parse markdown: unrecognized line: %q", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
+
+func parseMarkdownItem(text string) *Item {
+	switch {
+	case strings.HasPrefix(text, "[x] "):
+		return &Item{Name: strings.TrimPrefix(text, "[x] "), LayoutMode: workflowy.LayoutModeTodo, Completed: true}
+	case strings.HasPrefix(text, "[ ] "):
+		return &Item{Name: strings.TrimPrefix(text, "[ ] "), LayoutMode: workflowy.LayoutModeTodo}
+	case strings.HasPrefix(text, "### "):
+		return &Item{Name: strings.TrimPrefix(text, "### "), LayoutMode: workflowy.LayoutModeH3}
+	case strings.HasPrefix(text, "## "):
+		return &Item{Name: strings.TrimPrefix(text, "## "), LayoutMode: workflowy.LayoutModeH2}
+	case strings.HasPrefix(text, "# "):
+		return &Item{Name: strings.TrimPrefix(text, "# "), LayoutMode: workflowy.LayoutModeHeading1}
+	default:
+		return &Item{Name: text}
+	}
+}
@@ -0,0 +1,76 @@
+// Package format / opml.go implements OPML 2.0 conversion.
+package format
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// opmlDoc, opmlHead, and opmlBody mirror the OPML 2.0 document structure.
+type opmlDoc struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    opmlHead `xml:"head"`
+	Body    opmlBody `xml:"body"`
+}
+
+type opmlHead struct {
+	Title string `xml:"title"`
+}
+
+type opmlBody struct {
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// opmlOutline mirrors Workflowy's own OPML export: the node name is the "text" attribute and the
+// note, if any, is the "_note" attribute.
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Note     string        `xml:"_note,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// ToOPML serializes items as an OPML 2.0 document, matching Workflowy's own export format.
+func ToOPML(items []*Item) ([]byte, error) {
+	doc := opmlDoc{
+		Version: "2.0",
+		Head:    opmlHead{Title: "Workflowy Export"},
+		Body:    opmlBody{Outlines: toOPMLOutlines(items)},
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func toOPMLOutlines(items []*Item) []opmlOutline {
+	out := make([]opmlOutline, 0, len(items))
+	for _, item := range items {
+		out = append(out, opmlOutline{Text: item.Name, Note: item.Note, Outlines: toOPMLOutlines(item.Children)})
+	}
+	return out
+}
+
+// ParseOPML parses an OPML 2.0 document into an outline tree. Node names come from each
+// outline's "text" attribute, notes from "_note".
+func ParseOPML(r io.Reader) ([]*Item, error) {
+	var doc opmlDoc
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("format: parse OPML: %w", err)
+	}
+	return fromOPMLOutlines(doc.Body.Outlines), nil
+}
+
+func fromOPMLOutlines(outlines []opmlOutline) []*Item {
+	items := make([]*Item, 0, len(outlines))
+	for _, o := range outlines {
+		items = append(items, &Item{Name: o.Text, Note: o.Note, Children: fromOPMLOutlines(o.Outlines)})
+	}
+	return items
+}
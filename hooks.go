@@ -0,0 +1,46 @@
+// Package workflowy / hooks.go defines request-level middleware hooks and the per-operation
+// default timeout.
+package workflowy
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RoundTripHook observes one HTTP attempt after it completes, including retried attempts, so
+// observability tooling (logging, tracing, metrics) can see the retry pattern that is otherwise
+// hidden inside Client.do. attempt is 0 for the first try; resp is nil if err is a transport-
+// level error rather than an HTTP response.
+type RoundTripHook func(attempt int, req *http.Request, resp *http.Response, err error)
+
+// SetRoundTripHook registers fn to be called once per HTTP attempt. Passing nil disables it.
+func (c *Client) SetRoundTripHook(fn RoundTripHook) {
+	c.roundTripHook = fn
+}
+
+// SetRequestDecorator registers fn to be called on every request just before it is sent,
+// including retried attempts, so callers can plug in logging, custom auth-refresh logic, or
+// extra headers without replacing the whole HTTP client. Passing nil disables it.
+func (c *Client) SetRequestDecorator(fn func(*http.Request)) {
+	c.requestDecorator = fn
+}
+
+// SetDefaultTimeout makes every high-level call (GetNode, CreateNode, ...) apply d as a context
+// deadline whenever the caller's context doesn't already carry one of its own. Passing 0
+// disables it, the default.
+func (c *Client) SetDefaultTimeout(d time.Duration) {
+	c.defaultTimeout = d
+}
+
+// withDefaultTimeout derives a context with Client.defaultTimeout applied, unless ctx already
+// has a deadline or no default timeout is set. The returned cancel func is always safe to defer.
+func (c *Client) withDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.defaultTimeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.defaultTimeout)
+}
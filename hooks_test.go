@@ -0,0 +1,68 @@
+package workflowy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestClient_RequestDecoratorAndRoundTripHook checks that the decorator runs before the request
+// is sent and the hook observes the resulting response, once per attempt.
+func TestClient_RequestDecoratorAndRoundTripHook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "custom-value", r.Header.Get("X-Custom"))
+		w.Write([]byte(`{"node":{"id":"n1","name":"test"}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-token")
+	c.SetBaseURL(srv.URL)
+	c.SetRequestDecorator(func(req *http.Request) {
+		req.Header.Set("X-Custom", "custom-value")
+	})
+
+	var attempts []int
+	c.SetRoundTripHook(func(attempt int, req *http.Request, resp *http.Response, err error) {
+		attempts = append(attempts, attempt)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	node, err := c.GetNode(t.Context(), "n1")
+	require.NoError(t, err)
+	require.Equal(t, "n1", node.ID)
+	require.Equal(t, []int{0}, attempts)
+}
+
+// TestClient_SetDefaultTimeout checks that a call without its own deadline is bounded by the
+// default timeout, while a caller-supplied deadline is left untouched.
+func TestClient_SetDefaultTimeout(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	c := NewClient("test-token")
+	c.SetBaseURL(srv.URL)
+	c.SetHTTPClient(&http.Client{}) // no client-side timeout of its own
+	c.SetDefaultTimeout(20 * time.Millisecond)
+
+	start := time.Now()
+	_, err := c.GetNode(context.Background(), "n1")
+	require.Error(t, err)
+	require.Less(t, time.Since(start), time.Second)
+
+	// A caller-supplied deadline takes precedence over the default.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err = c.GetNode(ctx, "n1")
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 50*time.Millisecond)
+}
@@ -4,6 +4,7 @@ package workflowy
 // Node represents a Workflowy node as defined in the public API.
 type Node struct {
 	ID          string   `json:"id"`                    // UUID
+	ParentID    string   `json:"parent_id,omitempty"`   // ID of the parent node, empty for root-level nodes
 	Name        string   `json:"name"`                  // Main content of the node
 	Note        *string  `json:"note,omitempty"`        // Subtext
 	Priority    int      `json:"priority"`              // Sorting order, lower means higher on the list
@@ -0,0 +1,84 @@
+// Package workflowy / ratelimit.go defines the pluggable client-side rate limiter consulted by
+// Client.do before each request is sent.
+package workflowy
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter is consulted before each request is sent, so the client can pre-throttle itself
+// instead of relying on the server to return a 429.
+type RateLimiter interface {
+	// Wait blocks until req is allowed to be sent, or ctx is done.
+	Wait(ctx context.Context, req *http.Request) error
+}
+
+// pathRateLimiter pre-throttles specific endpoints known to be rate limited server-side, and lets
+// every other request through immediately.
+type pathRateLimiter struct {
+	buckets map[string]*tokenBucket
+}
+
+// DefaultRateLimiter returns the RateLimiter used by new Clients. It pre-throttles endpoints
+// documented as rate limited, so callers hit a client-side wait instead of burning a request to
+// discover a 429.
+func DefaultRateLimiter() RateLimiter {
+	return &pathRateLimiter{
+		buckets: map[string]*tokenBucket{
+			"/nodes-export": newTokenBucket(1.0/60, 1), // documented as 1 request per minute
+		},
+	}
+}
+
+// Wait implements RateLimiter.
+func (l *pathRateLimiter) Wait(ctx context.Context, req *http.Request) error {
+	for suffix, b := range l.buckets {
+		if strings.HasSuffix(req.URL.Path, suffix) {
+			return b.wait(ctx)
+		}
+	}
+	return nil
+}
+
+// tokenBucket is a simple token-bucket limiter: it starts full and refills at a fixed rate.
+type tokenBucket struct {
+	mu     sync.Mutex
+	tokens float64
+	max    float64
+	refill float64 // tokens per second
+	last   time.Time
+}
+
+// newTokenBucket creates a bucket holding up to burst tokens, refilled at rate tokens/second.
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{tokens: float64(burst), max: float64(burst), refill: rate}
+}
+
+// wait blocks until a token is available, or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		if !b.last.IsZero() {
+			b.tokens = min(b.max, b.tokens+now.Sub(b.last).Seconds()*b.refill)
+		}
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.refill * float64(time.Second))
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
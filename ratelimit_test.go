@@ -0,0 +1,47 @@
+package workflowy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestTokenBucket_WaitsForRefill checks that a single-token bucket lets the first call through
+// immediately and blocks the second until the bucket refills.
+func TestTokenBucket_WaitsForRefill(t *testing.T) {
+	b := newTokenBucket(10, 1) // 10 tokens/sec, burst of 1
+	ctx := t.Context()
+
+	start := time.Now()
+	require.NoError(t, b.wait(ctx))
+	require.Less(t, time.Since(start), 20*time.Millisecond)
+
+	start = time.Now()
+	require.NoError(t, b.wait(ctx))
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+// TestDefaultRateLimiter_OnlyThrottlesKnownPaths checks that the default limiter pre-throttles
+// the documented endpoint but leaves everything else unrestricted.
+func TestDefaultRateLimiter_OnlyThrottlesKnownPaths(t *testing.T) {
+	rl := DefaultRateLimiter()
+	c := NewClient("test-token")
+	ctx := t.Context()
+
+	req, err := c.newRequest(ctx, "GET", "/nodes", nil)
+	require.NoError(t, err)
+	start := time.Now()
+	require.NoError(t, rl.Wait(ctx, req))
+	require.Less(t, time.Since(start), 20*time.Millisecond)
+
+	exportReq, err := c.newRequest(ctx, "GET", "/nodes-export", nil)
+	require.NoError(t, err)
+	require.NoError(t, rl.Wait(ctx, exportReq)) // first call consumes the single burst token
+
+	shortCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	err = rl.Wait(shortCtx, exportReq)
+	require.Error(t, err) // second call within the same minute must wait far longer than 50ms
+}
@@ -0,0 +1,62 @@
+// Package workflowy / retry.go defines the pluggable retry policy used by Client.do.
+package workflowy
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// defaultMaxRetries is the number of retries the default RetryPolicy allows after the first
+// attempt, matching the client's previous fixed 429 retry budget.
+const defaultMaxRetries = 3
+
+// RetryPolicy decides whether Client.do should retry a request and how long to wait first. It is
+// consulted once per attempt, including attempts that already failed with a network error.
+type RetryPolicy interface {
+	// ShouldRetry is called after each attempt, attempt 0 being the first. resp is nil when err
+	// is a transport-level error rather than an HTTP response. If retry is true, the caller
+	// closes resp's body, waits, and tries again; ShouldRetry must not read resp.Body itself.
+	ShouldRetry(attempt int, req *http.Request, resp *http.Response, err error) (wait time.Duration, retry bool)
+}
+
+// defaultRetryPolicy retries 429s using the Retry-After header, and transient network errors or
+// 5xx responses using exponential backoff with jitter.
+type defaultRetryPolicy struct {
+	maxAttempts int
+	base        time.Duration
+	max         time.Duration
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used by new Clients.
+func DefaultRetryPolicy() RetryPolicy {
+	return &defaultRetryPolicy{maxAttempts: defaultMaxRetries, base: 500 * time.Millisecond, max: 30 * time.Second}
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p *defaultRetryPolicy) ShouldRetry(attempt int, req *http.Request, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.maxAttempts {
+		return 0, false
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		wait := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if wait <= 0 {
+			return 0, false
+		}
+		return wait, true
+	}
+	if err != nil || (resp != nil && resp.StatusCode >= 500) {
+		return p.backoff(attempt), true
+	}
+	return 0, false
+}
+
+// backoff computes an exponential backoff with up to 50% jitter, capped at p.max.
+func (p *defaultRetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.base * time.Duration(1<<attempt)
+	if wait > p.max {
+		wait = p.max
+	}
+	jitter := time.Duration(rand.Int63n(int64(wait) + 1))
+	return wait/2 + jitter/2
+}
@@ -0,0 +1,49 @@
+package workflowy
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestDefaultRetryPolicy_TooManyRequests checks that a 429 with a Retry-After header is retried
+// for the wait it specifies, and stops once the header is missing or attempts run out.
+func TestDefaultRetryPolicy_TooManyRequests(t *testing.T) {
+	p := DefaultRetryPolicy()
+	req, err := http.NewRequest(http.MethodGet, "https://example.org/nodes", nil)
+	require.NoError(t, err)
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"2"}}}
+	wait, retry := p.ShouldRetry(0, req, resp, nil)
+	require.True(t, retry)
+	require.Equal(t, 2*time.Second, wait)
+
+	respNoHeader := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	_, retry = p.ShouldRetry(0, req, respNoHeader, nil)
+	require.False(t, retry)
+
+	_, retry = p.ShouldRetry(defaultMaxRetries, req, resp, nil)
+	require.False(t, retry)
+}
+
+// TestDefaultRetryPolicy_TransientErrors checks that network errors and 5xx responses are
+// retried with a bounded backoff, and that 4xx responses other than 429 are not retried.
+func TestDefaultRetryPolicy_TransientErrors(t *testing.T) {
+	p := DefaultRetryPolicy()
+	req, err := http.NewRequest(http.MethodGet, "https://example.org/nodes", nil)
+	require.NoError(t, err)
+
+	wait, retry := p.ShouldRetry(0, req, nil, errors.New("connection reset"))
+	require.True(t, retry)
+	require.Greater(t, wait, time.Duration(0))
+
+	wait, retry = p.ShouldRetry(1, req, &http.Response{StatusCode: http.StatusInternalServerError}, nil)
+	require.True(t, retry)
+	require.Greater(t, wait, time.Duration(0))
+
+	_, retry = p.ShouldRetry(0, req, &http.Response{StatusCode: http.StatusBadRequest}, nil)
+	require.False(t, retry)
+}
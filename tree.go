@@ -0,0 +1,311 @@
+// Package workflowy / tree.go implements a local cache of the node tree, built from
+// Client.ExportAll and kept fresh with incremental refreshes.
+package workflowy
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// exportInterval is how often ExportAll may be called; it is documented as rate limited to once
+// per minute.
+const exportInterval = time.Minute
+
+// Tree is a local, in-memory cache of an account's node tree. It is built from Client.ExportAll
+// and organizes nodes into a parent/child graph, each parent's children sorted by Priority.
+type Tree struct {
+	c *Client
+
+	mu         sync.RWMutex
+	nodes      map[string]*Node
+	children   map[string][]string // parentID -> child IDs sorted by Priority; "" is the root
+	lastExport time.Time
+	watchers   []chan Event
+}
+
+// NewTree builds a Tree for c by calling Client.ExportAll.
+func (c *Client) NewTree(ctx context.Context) (*Tree, error) {
+	t := &Tree{c: c}
+	if err := t.reload(ctx); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Node returns the cached node with the given ID, or nil if it is not known.
+func (t *Tree) Node(id string) *Node {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.nodes[id]
+}
+
+// Children returns the direct children of parentID, sorted by Priority. Pass "" for root-level
+// nodes.
+func (t *Tree) Children(parentID string) []*Node {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	ids := t.children[parentID]
+	out := make([]*Node, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, t.nodes[id])
+	}
+	return out
+}
+
+// Walk calls fn for every cached node, depth-first in Priority order, starting from root-level
+// nodes.
+func (t *Tree) Walk(fn func(n *Node)) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var walk func(parentID string)
+	walk = func(parentID string) {
+		for _, id := range t.children[parentID] {
+			n := t.nodes[id]
+			fn(n)
+			walk(n.ID)
+		}
+	}
+	walk("")
+}
+
+// FindByName returns every cached node whose Name equals name.
+func (t *Tree) FindByName(name string) []*Node {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var out []*Node
+	for _, n := range t.nodes {
+		if n.Name == name {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// Refresh brings the tree up to date. If at least exportInterval has passed since the last
+// export, it re-runs ExportAll; otherwise it falls back to ListNodes calls against the root and
+// every node already known to the tree, since ExportAll is rate limited. A subtree under an entirely new,
+// not-yet-cached parent is only picked up once exportInterval has passed again.
+func (t *Tree) Refresh(ctx context.Context) error {
+	t.mu.RLock()
+	stale := time.Since(t.lastExport) >= exportInterval
+	t.mu.RUnlock()
+	if stale {
+		return t.reload(ctx)
+	}
+	return t.diffRefresh(ctx)
+}
+
+// Watch returns a channel of Events describing changes observed by future calls to Refresh. The
+// channel is closed once ctx is done.
+func (t *Tree) Watch(ctx context.Context) <-chan Event {
+	ch := make(chan Event, 16)
+
+	t.mu.Lock()
+	t.watchers = append(t.watchers, ch)
+	t.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		for i, w := range t.watchers {
+			if w == ch {
+				t.watchers = append(t.watchers[:i], t.watchers[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}()
+
+	return ch
+}
+
+// reload fully replaces the cache with a fresh Client.ExportAll, diffing against the previous
+// state to emit Events for whatever changed.
+func (t *Tree) reload(ctx context.Context) error {
+	exported, err := t.c.ExportAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	newNodes, newChildren := indexNodes(exported)
+
+	t.mu.Lock()
+	oldNodes := t.nodes
+	t.nodes = newNodes
+	t.children = newChildren
+	t.lastExport = time.Now()
+	t.mu.Unlock()
+
+	for id, n := range newNodes {
+		if o, ok := oldNodes[id]; ok {
+			for _, e := range diffNode(o, n) {
+				t.publish(e)
+			}
+		} else {
+			t.publish(Event{Type: EventCreate, Node: n})
+		}
+	}
+	for id, o := range oldNodes {
+		if _, ok := newNodes[id]; !ok {
+			t.publish(Event{Type: EventDelete, Node: o})
+		}
+	}
+	return nil
+}
+
+// diffRefresh re-lists the children of the root and of every node already known to the tree and
+// diffs the results against the cache. A node that disappears from one known parent's list is only
+// reported as deleted once every parent has been re-listed and the node hasn't turned up
+// elsewhere this round; otherwise an ordinary move (seen under its new parent) would race
+// against the stale entry under its old parent and surface as a spurious delete+create, with
+// the outcome depending on map-iteration order.
+func (t *Tree) diffRefresh(ctx context.Context) error {
+	t.mu.RLock()
+	parents := make([]string, 0, len(t.nodes)+1)
+	parents = append(parents, "")
+	for id := range t.nodes {
+		parents = append(parents, id)
+	}
+	staleChildren := make(map[string][]string, len(t.children))
+	for parentID, ids := range t.children {
+		staleChildren[parentID] = append([]string(nil), ids...)
+	}
+	t.mu.RUnlock()
+
+	var events []Event
+	stillPresent := make(map[string]bool)
+	for _, parentID := range parents {
+		children, err := t.c.ListNodes(ctx, parentID)
+		if err != nil {
+			return err
+		}
+		events = append(events, t.mergeChildren(parentID, children)...)
+		for _, n := range children {
+			stillPresent[n.ID] = true
+		}
+	}
+
+	t.mu.Lock()
+	for _, ids := range staleChildren {
+		for _, oldID := range ids {
+			if stillPresent[oldID] {
+				continue
+			}
+			if old, ok := t.nodes[oldID]; ok {
+				events = append(events, Event{Type: EventDelete, Node: old})
+				delete(t.nodes, oldID)
+			}
+		}
+	}
+	t.mu.Unlock()
+
+	for _, e := range events {
+		t.publish(e)
+	}
+	return nil
+}
+
+// mergeChildren updates the cache for one parent's children with whatever changed, replaces
+// that parent's sorted child ID list, and returns Events for creates/updates/moves. It never
+// deletes a node from the cache itself; a child missing from parentID's list may simply have
+// moved under a parent merged elsewhere this round, so disappearance is resolved by the caller
+// once every known parent has been re-listed.
+func (t *Tree) mergeChildren(parentID string, children []*Node) []Event {
+	sort.Slice(children, func(i, j int) bool { return children[i].Priority < children[j].Priority })
+
+	t.mu.Lock()
+	ids := make([]string, 0, len(children))
+	var events []Event
+	for _, n := range children {
+		ids = append(ids, n.ID)
+		if old, ok := t.nodes[n.ID]; ok {
+			events = append(events, diffNode(old, n)...)
+		} else {
+			events = append(events, Event{Type: EventCreate, Node: n})
+		}
+		t.nodes[n.ID] = n
+	}
+	t.children[parentID] = ids
+	t.mu.Unlock()
+
+	return events
+}
+
+// publish sends e to every registered watcher without blocking; a watcher that isn't keeping up
+// misses the event rather than stalling Refresh.
+func (t *Tree) publish(e Event) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, ch := range t.watchers {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// indexNodes builds a by-ID map and a parentID -> sorted child IDs map from a flat node list.
+func indexNodes(nodes []*Node) (map[string]*Node, map[string][]string) {
+	byID := make(map[string]*Node, len(nodes))
+	for _, n := range nodes {
+		byID[n.ID] = n
+	}
+	children := make(map[string][]string)
+	for _, n := range nodes {
+		children[n.ParentID] = append(children[n.ParentID], n.ID)
+	}
+	for parentID, ids := range children {
+		sort.Slice(ids, func(i, j int) bool { return byID[ids[i]].Priority < byID[ids[j]].Priority })
+		children[parentID] = ids
+	}
+	return byID, children
+}
+
+// diffNode compares two states of the same node and returns Events for whatever changed.
+func diffNode(old, n *Node) []Event {
+	var events []Event
+	if old.ParentID != n.ParentID {
+		events = append(events, Event{Type: EventMove, Node: n})
+	}
+	if old.Completed != n.Completed {
+		if n.Completed {
+			events = append(events, Event{Type: EventComplete, Node: n})
+		} else {
+			events = append(events, Event{Type: EventUncomplete, Node: n})
+		}
+	}
+	if old.Name != n.Name || !notesEqual(old.Note, n.Note) || old.Data.LayoutMode != n.Data.LayoutMode {
+		events = append(events, Event{Type: EventUpdate, Node: n})
+	}
+	return events
+}
+
+// notesEqual reports whether two optional note pointers hold the same value.
+func notesEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// EventType identifies what changed about a node between two Tree refreshes.
+type EventType int
+
+// EventType constants.
+const (
+	EventCreate EventType = iota
+	EventUpdate
+	EventMove
+	EventComplete
+	EventUncomplete
+	EventDelete
+)
+
+// Event describes one change observed between two Tree refreshes.
+type Event struct {
+	Type EventType
+	Node *Node // the node's state after the change; for EventDelete, its last known state
+}
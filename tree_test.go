@@ -0,0 +1,143 @@
+package workflowy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestTree_BuildAndNavigate builds a Tree from the live account and checks that Node, Children,
+// Walk, and FindByName agree with each other.
+func TestTree_BuildAndNavigate(t *testing.T) {
+	c := NewClient(testToken)
+	ctx := t.Context()
+
+	tr, err := c.NewTree(ctx)
+	require.NoError(t, err)
+
+	var walked int
+	tr.Walk(func(n *Node) {
+		walked++
+		require.NotNil(t, tr.Node(n.ID))
+		for _, child := range tr.Children(n.ID) {
+			require.Equal(t, n.ID, child.ParentID)
+		}
+	})
+	require.Greater(t, walked, 0)
+
+	name := "Tree Test Node"
+	nodeID, err := c.CreateNode(ctx, Create{Name: name, Position: &PositionBottom})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.DeleteNode(ctx, nodeID) })
+
+	found := tr.FindByName(name)
+	require.Empty(t, found, "node created after the last refresh should not be cached yet")
+}
+
+// TestTree_Refresh checks that Refresh picks up a newly created node and that Watch reports it
+// as an EventCreate.
+func TestTree_Refresh(t *testing.T) {
+	c := NewClient(testToken)
+	ctx := t.Context()
+
+	tr, err := c.NewTree(ctx)
+	require.NoError(t, err)
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := tr.Watch(watchCtx)
+
+	name := "Tree Refresh Test Node"
+	nodeID, err := c.CreateNode(ctx, Create{Name: name, Position: &PositionBottom})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.DeleteNode(ctx, nodeID) })
+
+	require.NoError(t, tr.Refresh(ctx))
+	require.NotNil(t, tr.Node(nodeID))
+
+	var sawCreate bool
+	for {
+		select {
+		case e := <-events:
+			if e.Type == EventCreate && e.Node.ID == nodeID {
+				sawCreate = true
+			}
+		default:
+			require.True(t, sawCreate, "expected an EventCreate for the new node")
+			return
+		}
+	}
+}
+
+// TestTree_Refresh_Move checks that moving a node between two parents that are both already
+// cached produces a single EventMove and leaves Tree.Node/Children consistent, rather than the
+// delete+create pair that a parent-by-parent diff can produce depending on iteration order.
+func TestTree_Refresh_Move(t *testing.T) {
+	c := NewClient(testToken)
+	ctx := t.Context()
+
+	oldParentID, err := c.CreateNode(ctx, Create{Name: "Tree Move Test Old Parent", Position: &PositionBottom})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.DeleteNode(ctx, oldParentID) })
+
+	newParentID, err := c.CreateNode(ctx, Create{Name: "Tree Move Test New Parent", Position: &PositionBottom})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.DeleteNode(ctx, newParentID) })
+
+	childID, err := c.CreateNode(ctx, Create{ParentID: oldParentID, Name: "Tree Move Test Child", Position: &PositionBottom})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.DeleteNode(ctx, childID) })
+
+	tr, err := c.NewTree(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, tr.Node(oldParentID), "old parent must be cached before the move")
+	require.NotNil(t, tr.Node(newParentID), "new parent must be cached before the move")
+	require.Contains(t, childIDs(tr.Children(oldParentID)), childID)
+
+	require.NoError(t, c.MoveNode(ctx, childID, Move{ParentID: newParentID}))
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := tr.Watch(watchCtx)
+
+	require.NoError(t, tr.Refresh(ctx))
+
+	child := tr.Node(childID)
+	require.NotNil(t, child, "moved node must still be cached")
+	require.Equal(t, newParentID, child.ParentID)
+	require.Contains(t, childIDs(tr.Children(newParentID)), childID)
+	require.NotContains(t, childIDs(tr.Children(oldParentID)), childID)
+
+	var moves, creates, deletes int
+	for done := false; !done; {
+		select {
+		case e := <-events:
+			if e.Node.ID != childID {
+				continue
+			}
+			switch e.Type {
+			case EventMove:
+				moves++
+			case EventCreate:
+				creates++
+			case EventDelete:
+				deletes++
+			}
+		default:
+			done = true
+		}
+	}
+	require.Equal(t, 1, moves, "expected exactly one EventMove for the child")
+	require.Zero(t, creates, "move must not surface as a spurious create")
+	require.Zero(t, deletes, "move must not surface as a spurious delete")
+}
+
+// childIDs extracts IDs from a slice of Nodes for use with require.Contains.
+func childIDs(nodes []*Node) []string {
+	ids := make([]string, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+	return ids
+}